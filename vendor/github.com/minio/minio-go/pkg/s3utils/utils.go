@@ -64,8 +64,8 @@ func IsValidIP(ip string) bool {
 }
 
 // IsVirtualHostSupported - verifies if bucketName can be part of
-// virtual host. Currently only Amazon S3 and Google Cloud Storage
-// would support this.
+// virtual host. Currently Amazon S3, Google Cloud Storage and any
+// provider registered in Providers with VirtualHostOK set support this.
 func IsVirtualHostSupported(endpointURL url.URL, bucketName string) bool {
 	if endpointURL == sentinelURL {
 		return false
@@ -75,8 +75,65 @@ func IsVirtualHostSupported(endpointURL url.URL, bucketName string) bool {
 	if endpointURL.Scheme == "https" && strings.Contains(bucketName, ".") {
 		return false
 	}
-	// Return true for all other cases
-	return IsAmazonEndpoint(endpointURL) || IsGoogleEndpoint(endpointURL)
+	if IsAmazonEndpoint(endpointURL) || IsAmazonFIPSEndpoint(endpointURL) || IsGoogleEndpoint(endpointURL) {
+		return true
+	}
+	for _, provider := range Providers {
+		if provider.Match(endpointURL) {
+			return provider.VirtualHostOK
+		}
+	}
+	return false
+}
+
+// Provider describes an S3-compatible storage provider that is not one of
+// the Amazon/Google endpoints handled natively by this package.
+type Provider struct {
+	// Match reports whether endpointURL belongs to this provider.
+	Match func(endpointURL url.URL) bool
+	// VirtualHostOK reports whether this provider supports virtual-host-style addressing.
+	VirtualHostOK bool
+	// Region derives the region from endpointURL, or "" if the provider is regionless.
+	Region func(endpointURL url.URL) string
+}
+
+// Providers holds additional S3-compatible providers consulted by
+// IsVirtualHostSupported and GetRegionFromURL after the built-in
+// Amazon/Google endpoint checks. Downstream packages can append their own
+// provider here, typically from an init function, e.g. for Wasabi,
+// DigitalOcean Spaces or Backblaze B2 S3-compatible endpoints, without
+// having to patch s3utils.
+var Providers []Provider
+
+func init() {
+	Providers = append(Providers, Provider{
+		Match:         IsAliyunOSSEndpoint,
+		VirtualHostOK: true,
+		Region:        getAliyunOSSRegion,
+	})
+}
+
+// IsAliyunOSSEndpoint - Match if it is exactly Aliyun OSS endpoint.
+func IsAliyunOSSEndpoint(endpointURL url.URL) bool {
+	if endpointURL == sentinelURL {
+		return false
+	}
+	return strings.HasSuffix(endpointURL.Host, ".aliyuncs.com")
+}
+
+// aliyunOSSHost - regular expression used to derive the region from an
+// Aliyun OSS host, e.g. "oss-cn-hangzhou.aliyuncs.com" -> "cn-hangzhou" and
+// "oss-cn-hangzhou-internal.aliyuncs.com" -> "cn-hangzhou".
+var aliyunOSSHost = regexp.MustCompile(`oss-([a-z0-9-]+?)(?:-internal)?\.aliyuncs\.com$`)
+
+// getAliyunOSSRegion - derives the region from an Aliyun OSS host, or ""
+// if it cannot be determined.
+func getAliyunOSSRegion(endpointURL url.URL) string {
+	parts := aliyunOSSHost.FindStringSubmatch(endpointURL.Host)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
 }
 
 // IsAmazonGovCloudEndpoint - Match if it is exactly Amazon S3 GovCloud endpoint.
@@ -96,13 +153,39 @@ func IsAmazonFIPSGovCloudEndpoint(endpointURL url.URL) bool {
 	return endpointURL.Host == "s3-fips-us-gov-west-1.amazonaws.com"
 }
 
+// AmazonS3Host - regular expression used to determine if an arg is s3 host.
+var AmazonS3Host = regexp.MustCompile(`^s3[.-]?(.*?)\.amazonaws\.com$`)
+
 // IsAmazonEndpoint - Match if it is exactly Amazon S3 endpoint.
 func IsAmazonEndpoint(endpointURL url.URL) bool {
 	if IsAmazonChinaEndpoint(endpointURL) {
 		return true
 	}
+	if endpointURL == sentinelURL {
+		return false
+	}
+	return AmazonS3Host.MatchString(endpointURL.Host)
+}
+
+// IsAmazonFIPSUSEastWestEndpoint - Match if it is exactly Amazon S3 FIPS US East/West endpoint.
+// See http://docs.aws.amazon.com/general/latest/gr/rande.html#s3_region for the list of
+// supported FIPS 140-2 endpoints outside of GovCloud.
+func IsAmazonFIPSUSEastWestEndpoint(endpointURL url.URL) bool {
+	if endpointURL == sentinelURL {
+		return false
+	}
+	switch endpointURL.Host {
+	case "s3-fips-us-east-1.amazonaws.com",
+		"s3-fips-us-west-1.amazonaws.com",
+		"s3-fips-us-west-2.amazonaws.com":
+		return true
+	}
+	return false
+}
 
-	return endpointURL.Host == "s3.amazonaws.com"
+// IsAmazonFIPSEndpoint - Match if it is exactly Amazon S3 FIPS or FIPS GovCloud endpoint.
+func IsAmazonFIPSEndpoint(endpointURL url.URL) bool {
+	return IsAmazonFIPSUSEastWestEndpoint(endpointURL) || IsAmazonFIPSGovCloudEndpoint(endpointURL)
 }
 
 // IsAmazonChinaEndpoint - Match if it is exactly Amazon S3 China endpoint.
@@ -118,6 +201,49 @@ func IsAmazonChinaEndpoint(endpointURL url.URL) bool {
 	return endpointURL.Host == "s3.cn-north-1.amazonaws.com.cn"
 }
 
+// amazonS3ChinaHost - regular expression used to determine the region of an
+// Amazon S3 China host, which does not match AmazonS3Host since it carries
+// the extra ".cn" TLD suffix.
+var amazonS3ChinaHost = regexp.MustCompile(`^s3\.(cn.*?)\.amazonaws\.com\.cn$`)
+
+// GetRegionFromURL - returns the region from url host, if it is empty
+// it is the default region "us-east-1".
+func GetRegionFromURL(endpointURL url.URL) string {
+	if endpointURL == sentinelURL {
+		return ""
+	}
+	if endpointURL.Host == "s3.amazonaws.com" {
+		return ""
+	}
+	if IsAmazonChinaEndpoint(endpointURL) {
+		parts := amazonS3ChinaHost.FindStringSubmatch(endpointURL.Host)
+		if len(parts) < 2 {
+			return ""
+		}
+		return parts[1]
+	}
+	if IsAmazonFIPSEndpoint(endpointURL) {
+		parts := AmazonS3Host.FindStringSubmatch(endpointURL.Host)
+		if len(parts) < 2 {
+			return ""
+		}
+		return strings.TrimPrefix(parts[1], "fips-")
+	}
+	if IsAmazonEndpoint(endpointURL) {
+		parts := AmazonS3Host.FindStringSubmatch(endpointURL.Host)
+		if len(parts) < 2 {
+			return ""
+		}
+		return parts[1]
+	}
+	for _, provider := range Providers {
+		if provider.Match(endpointURL) {
+			return provider.Region(endpointURL)
+		}
+	}
+	return ""
+}
+
 // IsGoogleEndpoint - Match if it is exactly Google cloud storage endpoint.
 func IsGoogleEndpoint(endpointURL url.URL) bool {
 	if endpointURL == sentinelURL {
@@ -126,6 +252,96 @@ func IsGoogleEndpoint(endpointURL url.URL) bool {
 	return endpointURL.Host == "storage.googleapis.com"
 }
 
+// ErrInvalidBucketName - Invalid bucket name response.
+type ErrInvalidBucketName struct {
+	message string
+}
+
+func (e ErrInvalidBucketName) Error() string {
+	return e.message
+}
+
+// ErrInvalidObjectName - Invalid object name response.
+type ErrInvalidObjectName struct {
+	message string
+}
+
+func (e ErrInvalidObjectName) Error() string {
+	return e.message
+}
+
+// Domain regexp used by the bucket name validators below.
+var (
+	validBucketName       = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9\.\-\_\:]{1,61}[A-Za-z0-9]$`)
+	validBucketNameStrict = regexp.MustCompile(`^[a-z0-9][a-z0-9\-]{1,61}[a-z0-9]$`)
+	ipAddress             = regexp.MustCompile(`^(\d+\.){3}\d+$`)
+)
+
+// CheckValidBucketName - checks if we have a valid input bucket name.
+func CheckValidBucketName(bucketName string) (err error) {
+	return checkBucketNameCommon(bucketName, false)
+}
+
+// CheckValidBucketNameStrict - checks if we have a valid input bucket name.
+// This is a stricter version.
+// - Check if bucketName contains only lowercase alphanumeric characters and '-' ('.' is not allowed).
+// - Check if bucketName starts with alphabet or number.
+// - Check if bucketName is between [3, 63] characters long.
+// - Check if bucketName does not end with '-'.
+// - Check if bucketName does not have successive periods.
+// - Check if bucketName is not formatted as an IP address.
+func CheckValidBucketNameStrict(bucketName string) (err error) {
+	return checkBucketNameCommon(bucketName, true)
+}
+
+// checkBucketNameCommon - checks if we have a valid input bucket name.
+func checkBucketNameCommon(bucketName string, strict bool) (err error) {
+	if strings.TrimSpace(bucketName) == "" {
+		return ErrInvalidBucketName{message: "Bucket name cannot be empty"}
+	}
+	if len(bucketName) < 3 {
+		return ErrInvalidBucketName{message: "Bucket name cannot be smaller than 3 characters"}
+	}
+	if len(bucketName) > 63 {
+		return ErrInvalidBucketName{message: "Bucket name cannot be greater than 63 characters"}
+	}
+	if ipAddress.MatchString(bucketName) {
+		return ErrInvalidBucketName{message: "Bucket name cannot be an ip address"}
+	}
+	if strings.Contains(bucketName, "..") {
+		return ErrInvalidBucketName{message: "Bucket name cannot have successive periods"}
+	}
+	if strict {
+		if !validBucketNameStrict.MatchString(bucketName) {
+			return ErrInvalidBucketName{message: "Bucket name contains invalid characters"}
+		}
+		return nil
+	}
+	if !validBucketName.MatchString(bucketName) {
+		return ErrInvalidBucketName{message: "Bucket name contains invalid characters"}
+	}
+	return nil
+}
+
+// CheckValidObjectNamePrefix - checks if we have a valid input object name prefix.
+func CheckValidObjectNamePrefix(objectName string) error {
+	if len(objectName) > 1024 {
+		return ErrInvalidObjectName{message: "Object name cannot be greater than 1024 bytes"}
+	}
+	if !utf8.ValidString(objectName) {
+		return ErrInvalidObjectName{message: "Object name with non UTF-8 strings are not supported"}
+	}
+	return nil
+}
+
+// CheckValidObjectName - checks if we have a valid input object name.
+func CheckValidObjectName(objectName string) error {
+	if strings.TrimSpace(objectName) == "" {
+		return ErrInvalidObjectName{message: "Object name cannot be empty"}
+	}
+	return CheckValidObjectNamePrefix(objectName)
+}
+
 // Expects ascii encoded strings - from output of urlEncodePath
 func percentEncodeSlash(s string) string {
 	return strings.Replace(s, "/", "%2F", -1)